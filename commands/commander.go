@@ -3,16 +3,19 @@ package commands
 import (
 	"fmt"
 	"log"
-	"manuscript-core/pkg"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 )
 
 // Define global CLI variables
 var (
-	env     string
-	version = "1.1.0"
+	env         string
+	stackDryRun bool
+	socketPath  string
+	deployWait  time.Duration
+	version     = "1.1.0"
 )
 
 // Execute runs the CLI commands
@@ -77,22 +80,15 @@ Status indicators:
 ⚪️ Other - Various other states
 
 Usage:
-- Run without arguments to check default directory
-- Specify a directory path to check manuscripts in that location`,
+- Run without arguments to list every manuscript in the config
+- Specify a directory path to scope the listing to manuscripts deployed there`,
 	Example: `>> manuscript-cli ls
 >> manuscript-cli list /path/to/manuscripts`,
 	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		var dir string
-		// if no args, use default manuscript directory
-		if len(args) == 0 {
-			config, err := pkg.LoadConfig(manuscriptConfig)
-			if err != nil {
-				log.Fatalf("Error: Failed to load config: %v", err)
-			}
-			dir = fmt.Sprintf("%s/%s", config.BaseDir, manuscriptBaseName)
-		} else {
-			dir = args[0] // use specified directory if user input
+		if len(args) > 0 {
+			dir = args[0]
 		}
 		ListJobs(dir)
 	},
@@ -206,6 +202,55 @@ Requirements:
 	},
 }
 
+// `deploy-stack` command deploys a directory of manuscripts in dependency order
+var deployStackCmd = &cobra.Command{
+	Use:   "deploy-stack <stack-dir>",
+	Short: "Deploy a directory of manuscripts in dependency order",
+	Long: `🧱 Deploy a Manuscript Stack
+
+A stack directory holds a stack.yaml declaring each manuscript and the
+depends_on relationships between them. Manuscripts are deployed in
+topological order, sharing a Docker network; if any step fails, every
+manuscript already deployed in this run is rolled back.
+
+Use --dry-run to print the computed deploy order and port assignments
+without touching Docker or the filesystem.`,
+	Example: `>> manuscript-cli deploy-stack ./my-stack
+>> manuscript-cli deploy-stack ./my-stack --dry-run`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := DeployManuscriptStack(args[0], stackDryRun); err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+	},
+}
+
+// `serve` command hosts the manuscript bindings over a local Unix socket
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Host manuscript deployments over a local socket",
+	Long: `🔌 Run manuscript-cli as a Background Service
+
+Exposes the same Deploy/Stop/List/Logs bindings the CLI itself uses over a
+local Unix socket as a small REST+SSE API, so other tools (an IDE plugin,
+a web dashboard, or a remote chat session) can drive deployments without
+re-executing the CLI.
+
+Endpoints:
+🔷 POST /deploy {"manuscript_path": "..."}
+🔷 POST /stop   {"name": "..."}
+🔷 GET  /list
+🔷 GET  /logs?name=...  (text/event-stream)`,
+	Example: `>> manuscript-cli serve
+>> manuscript-cli serve --socket /tmp/my.sock`,
+	Args: cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := Serve(socketPath); err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+	},
+}
+
 // `version` command shows the version of manuscript-cli
 var versionCmd = &cobra.Command{
 	Use:   "version",
@@ -246,6 +291,7 @@ func addCommands() {
 	// Manuscript creation & deployment commands
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(deployManuscript)
+	rootCmd.AddCommand(deployStackCmd)
 
 	// Job management commands
 	rootCmd.AddCommand(jobListCmd)
@@ -257,6 +303,7 @@ func addCommands() {
 
 	// Utility commands
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(serveCmd)
 
 }
 
@@ -264,9 +311,17 @@ func configureFlags() {
 	// Configure deployment flags
 	deployManuscript.Flags().StringVar(&env, "env", "", "Specify the environment to deploy (local or chainbase)")
 	deployManuscript.MarkFlagRequired("env")
+	deployManuscript.Flags().DurationVar(&deployWait, "wait", 90*time.Second, "How long to wait for deployed services to become healthy")
+
+	// Configure deploy-stack flags
+	deployStackCmd.Flags().BoolVar(&stackDryRun, "dry-run", false, "Print the deploy plan without deploying anything")
+	deployStackCmd.Flags().DurationVar(&deployWait, "wait", 90*time.Second, "How long to wait for each manuscript's services to become healthy")
 
 	// Configure version command flags
 	versionCmd.Flags().BoolP("verbose", "v", false, "Display detailed version information")
+
+	// Configure serve command flags
+	serveCmd.Flags().StringVar(&socketPath, "socket", "", fmt.Sprintf("Unix socket to listen on (default %s)", defaultSocketPath))
 }
 
 var rootCmd = &cobra.Command{
@@ -0,0 +1,221 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"manuscript-core/pkg"
+	"manuscript-core/pkg/docker"
+	"manuscript-core/pkg/manuscript"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// stackManifest is the top-level `stack.yaml` a `deploy-stack` directory
+// must contain, declaring every manuscript in the stack and the order
+// constraints between them.
+type stackManifest struct {
+	Manuscripts []stackManuscript `yaml:"manuscripts"`
+}
+
+// stackManuscript describes one manuscript within a stack: where its
+// manuscript.yaml lives (relative to the stack directory) and which other
+// manuscripts in the stack it depends on.
+type stackManuscript struct {
+	Name      string   `yaml:"name"`
+	Path      string   `yaml:"path"`
+	DependsOn []string `yaml:"depends_on"`
+}
+
+// deployedManuscript tracks what a single stack step actually did, so a
+// failure partway through the stack can be unwound in reverse.
+type deployedManuscript struct {
+	ms            pkg.Manuscript
+	manuscriptDir string
+}
+
+// DeployManuscriptStack deploys every manuscript declared in stackDir's
+// stack.yaml, in dependency order, sharing a single Docker network. If any
+// manuscript fails to deploy, every manuscript already deployed in this run
+// is torn down in reverse order and the shared network is removed; whatever
+// the failing manuscript's own deploy left behind (its directory or
+// containers) is not cleaned up. When
+// dryRun is true, nothing is deployed or touched on disk/Docker - the
+// computed deploy order and port assignments are printed and the function
+// returns.
+func DeployManuscriptStack(stackDir string, dryRun bool) error {
+	manifest, err := loadStackManifest(stackDir)
+	if err != nil {
+		return fmt.Errorf("failed to load stack manifest: %w", err)
+	}
+
+	order, err := topologicalSort(manifest.Manuscripts)
+	if err != nil {
+		return fmt.Errorf("failed to order stack manuscripts: %w", err)
+	}
+
+	if dryRun {
+		return printStackPlan(stackDir, order)
+	}
+
+	networkName := fmt.Sprintf("manuscript-stack-%s", filepath.Base(stackDir))
+	dockerClient, err := docker.NewClient()
+	if err != nil {
+		return fmt.Errorf("failed to create docker client: %w", err)
+	}
+	defer dockerClient.Close()
+
+	networkID, err := dockerClient.CreateNetwork(context.Background(), networkName)
+	if err != nil {
+		return fmt.Errorf("failed to create shared network %s: %w", networkName, err)
+	}
+
+	baseDir := resolveManuscriptBaseDir()
+
+	var deployed []deployedManuscript
+	for _, sm := range order {
+		manuscriptPath := filepath.Join(stackDir, sm.Path)
+		log.Printf("\033[36m→ Deploying %s (%s)\n", sm.Name, manuscriptPath)
+
+		resp, err := manuscript.Deploy(context.Background(), manuscript.DeployRequest{
+			ManuscriptPath:     manuscriptPath,
+			BaseDir:            baseDir,
+			ManuscriptBaseName: manuscriptBaseName,
+			ConfigPath:         manuscriptConfig,
+			WaitTimeout:        deployWait,
+			Network:            networkName,
+		})
+		if err != nil {
+			log.Printf("\033[31m✗ %s failed: %v\n", sm.Name, err)
+			rollbackStack(dockerClient, networkID, deployed)
+			return fmt.Errorf("stack deploy aborted at %s: %w", sm.Name, err)
+		}
+
+		deployed = append(deployed, deployedManuscript{ms: resp.Manuscript, manuscriptDir: resp.Dir})
+	}
+
+	fmt.Printf("\033[32m✓ Stack deployment completed successfully! (%d manuscripts)\n", len(deployed))
+	return nil
+}
+
+// loadStackManifest reads and parses stackDir/stack.yaml.
+func loadStackManifest(stackDir string) (*stackManifest, error) {
+	path := filepath.Join(stackDir, "stack.yaml")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var manifest stackManifest
+	if err := yaml.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if len(manifest.Manuscripts) == 0 {
+		return nil, fmt.Errorf("%s declares no manuscripts", path)
+	}
+	return &manifest, nil
+}
+
+// topologicalSort orders manuscripts so that every manuscript appears after
+// everything it depends_on, using Kahn's algorithm. It returns an error if
+// depends_on references an unknown manuscript or the graph has a cycle.
+func topologicalSort(manuscripts []stackManuscript) ([]stackManuscript, error) {
+	byName := make(map[string]stackManuscript, len(manuscripts))
+	inDegree := make(map[string]int, len(manuscripts))
+	dependents := make(map[string][]string, len(manuscripts))
+
+	for _, sm := range manuscripts {
+		byName[sm.Name] = sm
+		if _, ok := inDegree[sm.Name]; !ok {
+			inDegree[sm.Name] = 0
+		}
+	}
+
+	for _, sm := range manuscripts {
+		for _, dep := range sm.DependsOn {
+			if _, ok := byName[dep]; !ok {
+				return nil, fmt.Errorf("manuscript %s depends_on unknown manuscript %s", sm.Name, dep)
+			}
+			inDegree[sm.Name]++
+			dependents[dep] = append(dependents[dep], sm.Name)
+		}
+	}
+
+	var queue []string
+	for _, sm := range manuscripts {
+		if inDegree[sm.Name] == 0 {
+			queue = append(queue, sm.Name)
+		}
+	}
+
+	var order []stackManuscript
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		order = append(order, byName[name])
+
+		for _, dependent := range dependents[name] {
+			inDegree[dependent]--
+			if inDegree[dependent] == 0 {
+				queue = append(queue, dependent)
+			}
+		}
+	}
+
+	if len(order) != len(manuscripts) {
+		return nil, fmt.Errorf("depends_on graph has a cycle")
+	}
+	return order, nil
+}
+
+// printStackPlan prints the deploy order and the ports each manuscript
+// would receive, without touching Docker or the filesystem. Ports are
+// computed against an in-memory copy of the config that accumulates each
+// manuscript's assignment as the loop proceeds, the same way a real
+// sequential deploy would, so later manuscripts in the stack don't get
+// port assignments that collide with earlier ones in the plan.
+func printStackPlan(stackDir string, order []stackManuscript) error {
+	config, err := pkg.LoadConfig(manuscriptConfig)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	fmt.Println("\033[36mDry run: planned deploy order\033[0m")
+	for i, sm := range order {
+		manuscriptPath := filepath.Join(stackDir, sm.Path)
+		ms, err := pkg.ParseYAML(manuscriptPath)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", manuscriptPath, err)
+		}
+		if err := pkg.InitializePorts(ms, config); err != nil {
+			return fmt.Errorf("failed to compute ports for %s: %w", sm.Name, err)
+		}
+		config.Manuscripts = append(config.Manuscripts, *ms)
+
+		fmt.Printf("  %d. %s (flink=%d graphql=%d db=%d)\n", i+1, sm.Name, ms.Port, ms.GraphQLPort, ms.DbPort)
+	}
+	return nil
+}
+
+// rollbackStack compensates for a partially deployed stack by tearing down
+// every manuscript already deployed this run, in reverse order, then
+// removing the shared network.
+func rollbackStack(dockerClient *docker.Client, networkID string, deployed []deployedManuscript) {
+	for i := len(deployed) - 1; i >= 0; i-- {
+		d := deployed[i]
+		log.Printf("\033[33m↩ rolling back %s\n", d.ms.Name)
+
+		if err := manuscript.Stop(context.Background(), d.ms.Name, manuscriptConfig); err != nil {
+			log.Printf("\033[31mwarning: failed to stop %s during rollback: %v\n", d.ms.Name, err)
+		}
+		if err := os.RemoveAll(d.manuscriptDir); err != nil {
+			log.Printf("\033[31mwarning: failed to remove %s during rollback: %v\n", d.manuscriptDir, err)
+		}
+	}
+
+	if err := dockerClient.RemoveNetwork(context.Background(), networkID); err != nil {
+		log.Printf("\033[31mwarning: failed to remove shared network during rollback: %v\n", err)
+	}
+}
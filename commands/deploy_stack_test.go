@@ -0,0 +1,62 @@
+package commands
+
+import "testing"
+
+func TestTopologicalSort(t *testing.T) {
+	t.Run("orders dependents after their dependencies", func(t *testing.T) {
+		manuscripts := []stackManuscript{
+			{Name: "c", DependsOn: []string{"b"}},
+			{Name: "b", DependsOn: []string{"a"}},
+			{Name: "a"},
+		}
+
+		order, err := topologicalSort(manuscripts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		index := make(map[string]int, len(order))
+		for i, sm := range order {
+			index[sm.Name] = i
+		}
+		if index["a"] > index["b"] || index["b"] > index["c"] {
+			t.Fatalf("expected order a, b, c; got %v", order)
+		}
+	})
+
+	t.Run("independent manuscripts keep a valid order", func(t *testing.T) {
+		manuscripts := []stackManuscript{
+			{Name: "a"},
+			{Name: "b"},
+		}
+
+		order, err := topologicalSort(manuscripts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(order) != 2 {
+			t.Fatalf("expected 2 manuscripts in order, got %d", len(order))
+		}
+	})
+
+	t.Run("unknown dependency is an error", func(t *testing.T) {
+		manuscripts := []stackManuscript{
+			{Name: "a", DependsOn: []string{"missing"}},
+		}
+
+		if _, err := topologicalSort(manuscripts); err == nil {
+			t.Fatal("expected an error for an unknown depends_on reference, got nil")
+		}
+	})
+
+	t.Run("cycle is an error", func(t *testing.T) {
+		manuscripts := []stackManuscript{
+			{Name: "a", DependsOn: []string{"b"}},
+			{Name: "b", DependsOn: []string{"a"}},
+		}
+
+		if _, err := topologicalSort(manuscripts); err == nil {
+			t.Fatal("expected an error for a depends_on cycle, got nil")
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"manuscript-core/pkg/manuscript"
+	"os"
+	"os/signal"
+)
+
+// ListJobs is a thin cobra-facing wrapper around manuscript.List. If dir is
+// non-empty, only manuscripts whose directory (<dir>/<name>) exists on disk
+// are printed, so `list /path/to/manuscripts` still scopes the listing to
+// manuscripts under that location; an empty dir lists everything the
+// config knows about.
+func ListJobs(dir string) {
+	manuscripts, err := manuscript.List(manuscriptConfig)
+	if err != nil {
+		log.Fatalf("Error: Failed to list manuscripts: %v", err)
+	}
+
+	if dir != "" {
+		filtered := manuscripts[:0]
+		for _, ms := range manuscripts {
+			if _, err := os.Stat(fmt.Sprintf("%s/%s", dir, ms.Name)); err == nil {
+				filtered = append(filtered, ms)
+			}
+		}
+		manuscripts = filtered
+	}
+
+	if len(manuscripts) == 0 {
+		fmt.Println("No manuscripts deployed.")
+		return
+	}
+	for _, ms := range manuscripts {
+		fmt.Printf("%s\tflink=%d\tgraphql=%d\tdb=%d\n", ms.Name, ms.Port, ms.GraphQLPort, ms.DbPort)
+	}
+}
+
+// JobStop is a thin cobra-facing wrapper around manuscript.Stop.
+func JobStop(name string) {
+	if err := manuscript.Stop(context.Background(), name, manuscriptConfig); err != nil {
+		log.Fatalf("\033[31m✗ failed to stop %s: %v\n", name, err)
+	}
+	fmt.Printf("\033[32m✓ Stopped manuscript [ %s ]\n", name)
+}
+
+// JobLogs is a thin cobra-facing wrapper around manuscript.Logs: it
+// streams the manuscript's jobmanager log output to stdout until
+// interrupted.
+func JobLogs(name string) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	go func() {
+		<-sigCh
+		cancel()
+	}()
+
+	lines, err := manuscript.Logs(ctx, name)
+	if err != nil {
+		log.Fatalf("Error: Failed to stream logs for %s: %v", name, err)
+	}
+
+	for line := range lines {
+		fmt.Println(line.Line)
+	}
+}
@@ -0,0 +1,158 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"manuscript-core/pkg/manuscript"
+	"net"
+	"net/http"
+	"os"
+)
+
+// defaultSocketPath is where `serve` listens by default; overridden with
+// the `--socket` flag.
+const defaultSocketPath = "/tmp/manuscript-cli.sock"
+
+// Serve hosts the manuscript bindings over a local Unix socket as a small
+// REST+SSE service, so external tools (an IDE plugin, a web dashboard, or
+// a remote `chat` session) can drive deployments without re-executing the
+// CLI. The cobra commands in this package are themselves thin clients of
+// the same manuscript.Deploy/Stop/List/Logs bindings this serves.
+func Serve(socketPath string) error {
+	if socketPath == "" {
+		socketPath = defaultSocketPath
+	}
+	if err := os.RemoveAll(socketPath); err != nil {
+		return fmt.Errorf("failed to clear existing socket: %w", err)
+	}
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", socketPath, err)
+	}
+	defer listener.Close()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/deploy", handleDeploy)
+	mux.HandleFunc("/stop", handleStop)
+	mux.HandleFunc("/list", handleList)
+	mux.HandleFunc("/logs", handleLogs)
+
+	log.Printf("manuscript-cli serving on unix://%s\n", socketPath)
+	return http.Serve(listener, mux)
+}
+
+type deployRequestBody struct {
+	ManuscriptPath string `json:"manuscript_path"`
+}
+
+func handleDeploy(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var body deployRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := manuscript.Deploy(r.Context(), manuscript.DeployRequest{
+		ManuscriptPath:     body.ManuscriptPath,
+		BaseDir:            resolveManuscriptBaseDir(),
+		ManuscriptBaseName: manuscriptBaseName,
+		ConfigPath:         manuscriptConfig,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+type stopRequestBody struct {
+	Name string `json:"name"`
+}
+
+func handleStop(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var body stopRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := manuscript.Stop(r.Context(), body.Name, manuscriptConfig); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, map[string]string{"status": "stopped"})
+}
+
+func handleList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	manuscripts, err := manuscript.List(manuscriptConfig)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, manuscripts)
+}
+
+func handleLogs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		http.Error(w, "missing required query parameter: name", http.StatusBadRequest)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	lines, err := manuscript.Logs(ctx, name)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	for line := range lines {
+		fmt.Fprintf(w, "data: %s\n\n", line.Line)
+		flusher.Flush()
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("failed to encode response: %v\n", err)
+	}
+}
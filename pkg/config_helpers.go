@@ -0,0 +1,26 @@
+package pkg
+
+import "fmt"
+
+// RemoveManuscriptFromConfig deletes the manuscript named name from the
+// config at path, freeing the ports it had reserved. It is a no-op if the
+// manuscript isn't present.
+func RemoveManuscriptFromConfig(path, name string) error {
+	config, err := LoadConfig(path)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	remaining := make([]Manuscript, 0, len(config.Manuscripts))
+	for _, ms := range config.Manuscripts {
+		if ms.Name != name {
+			remaining = append(remaining, ms)
+		}
+	}
+	config.Manuscripts = remaining
+
+	if err := SaveConfig(path, config); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	return nil
+}
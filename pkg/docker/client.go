@@ -0,0 +1,209 @@
+// Package docker wraps the Docker Engine Go SDK so the rest of manuscript-core
+// can manage containers without shelling out to the docker/docker-compose
+// binaries. Callers get structured results and real errors instead of text
+// scraped from CLI output.
+package docker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+)
+
+// Client is a thin wrapper around the Docker Engine API client that exposes
+// only the operations manuscript-core needs to deploy and manage a
+// manuscript's Flink/Postgres/Hasura stack.
+type Client struct {
+	cli *client.Client
+}
+
+// NewClient builds a Client from the environment (DOCKER_HOST, TLS certs,
+// etc.), the same way the docker CLI itself resolves its connection.
+func NewClient() (*Client, error) {
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create docker client: %w", err)
+	}
+	return &Client{cli: cli}, nil
+}
+
+// Close releases the underlying connection to the Docker daemon.
+func (c *Client) Close() error {
+	return c.cli.Close()
+}
+
+// ContainerInfo is the subset of container state manuscript-core cares about.
+type ContainerInfo struct {
+	ID             string
+	Name           string
+	Image          string
+	State          string
+	Status         string
+	PublishedPorts []int
+}
+
+// ListContainers returns all containers (running or not) known to the
+// daemon, replacing the old `docker ps` text-parsing path.
+func (c *Client) ListContainers(ctx context.Context) ([]ContainerInfo, error) {
+	containers, err := c.cli.ContainerList(ctx, types.ContainerListOptions{All: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	infos := make([]ContainerInfo, 0, len(containers))
+	for _, ctr := range containers {
+		name := ctr.ID
+		if len(ctr.Names) > 0 {
+			name = trimLeadingSlash(ctr.Names[0])
+		}
+		var publishedPorts []int
+		for _, p := range ctr.Ports {
+			if p.PublicPort != 0 {
+				publishedPorts = append(publishedPorts, int(p.PublicPort))
+			}
+		}
+
+		infos = append(infos, ContainerInfo{
+			ID:             ctr.ID,
+			Name:           name,
+			Image:          ctr.Image,
+			State:          ctr.State,
+			Status:         ctr.Status,
+			PublishedPorts: publishedPorts,
+		})
+	}
+	return infos, nil
+}
+
+// CreateContainer creates (but does not start) a container from the given
+// config; the network it joins is the one hostCfg.NetworkMode names.
+func (c *Client) CreateContainer(ctx context.Context, name string, cfg *container.Config, hostCfg *container.HostConfig) (string, error) {
+	resp, err := c.cli.ContainerCreate(ctx, cfg, hostCfg, nil, nil, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to create container %s: %w", name, err)
+	}
+	return resp.ID, nil
+}
+
+// StartService starts a previously created container by ID.
+func (c *Client) StartService(ctx context.Context, containerID string) error {
+	if err := c.cli.ContainerStart(ctx, containerID, types.ContainerStartOptions{}); err != nil {
+		return fmt.Errorf("failed to start container %s: %w", containerID, err)
+	}
+	return nil
+}
+
+// StreamLogs returns a reader of the container's combined stdout/stderr log
+// stream, following new output as it is produced.
+func (c *Client) StreamLogs(ctx context.Context, containerID string) (io.ReadCloser, error) {
+	rc, err := c.cli.ContainerLogs(ctx, containerID, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     true,
+		Tail:       "200",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to stream logs for %s: %w", containerID, err)
+	}
+	return rc, nil
+}
+
+// healthBackoffInitial and healthBackoffMax bound the exponential backoff
+// WaitHealthy uses between polls, so a slow-starting Flink/Hasura stack
+// isn't hammered with inspect calls while it comes up.
+const (
+	healthBackoffInitial = 500 * time.Millisecond
+	healthBackoffMax     = 8 * time.Second
+)
+
+// WaitHealthy polls a container's health status, backing off exponentially
+// between checks, until it reports "healthy", the container exits, or the
+// timeout elapses. Use this instead of checking once right after starting a
+// container - Flink/Hasura/Postgres all take a few seconds to come up, and a
+// one-shot check just after `start` races that.
+func (c *Client) WaitHealthy(ctx context.Context, containerID string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	backoff := healthBackoffInitial
+	for {
+		inspect, err := c.cli.ContainerInspect(ctx, containerID)
+		if err != nil {
+			return fmt.Errorf("failed to inspect container %s: %w", containerID, err)
+		}
+
+		if inspect.State.Status == "exited" || inspect.State.Status == "dead" {
+			return fmt.Errorf("container %s exited before becoming healthy", containerID)
+		}
+
+		if inspect.State.Health == nil {
+			// No healthcheck defined; fall back to "running" as the best
+			// signal we have.
+			if inspect.State.Running {
+				return nil
+			}
+		} else {
+			switch inspect.State.Health.Status {
+			case types.Healthy:
+				return nil
+			case types.Unhealthy:
+				return fmt.Errorf("container %s is unhealthy", containerID)
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for container %s to become healthy", containerID)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > healthBackoffMax {
+			backoff = healthBackoffMax
+		}
+	}
+}
+
+// RemoveStack force-removes every container in containerIDs, used to roll
+// back a partially deployed stack.
+func (c *Client) RemoveStack(ctx context.Context, containerIDs []string) error {
+	var lastErr error
+	for _, id := range containerIDs {
+		if err := c.cli.ContainerRemove(ctx, id, types.ContainerRemoveOptions{Force: true}); err != nil {
+			lastErr = fmt.Errorf("failed to remove container %s: %w", id, err)
+		}
+	}
+	return lastErr
+}
+
+// CreateNetwork creates a user-defined bridge network, used to let the
+// containers in a multi-manuscript stack reach each other by name.
+func (c *Client) CreateNetwork(ctx context.Context, name string) (string, error) {
+	resp, err := c.cli.NetworkCreate(ctx, name, types.NetworkCreate{Driver: "bridge"})
+	if err != nil {
+		return "", fmt.Errorf("failed to create network %s: %w", name, err)
+	}
+	return resp.ID, nil
+}
+
+// RemoveNetwork deletes a network previously created with CreateNetwork.
+func (c *Client) RemoveNetwork(ctx context.Context, networkID string) error {
+	if err := c.cli.NetworkRemove(ctx, networkID); err != nil {
+		return fmt.Errorf("failed to remove network %s: %w", networkID, err)
+	}
+	return nil
+}
+
+func trimLeadingSlash(name string) string {
+	if len(name) > 0 && name[0] == '/' {
+		return name[1:]
+	}
+	return name
+}
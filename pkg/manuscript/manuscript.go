@@ -0,0 +1,460 @@
+// Package manuscript is the programmatic bindings surface for
+// manuscript-core: typed request/response APIs that deploy, stop, list and
+// tail the logs of a manuscript without exiting the process: results and
+// failures come back as values and errors. The only terminal output is the
+// progress spinner Deploy shows (via pkg.ExecuteStepWithLoading) while it
+// waits for services to become healthy, and the warnings Stop logs when
+// network cleanup fails. This is what `manuscript-cli`'s cobra commands call into, and
+// what the `serve` command exposes over a local socket - both are thin
+// clients of the same bindings, mirroring how podman split cmd/podmanV2
+// from pkg/bindings.
+package manuscript
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"manuscript-core/pkg"
+	"manuscript-core/pkg/docker"
+	"os"
+	"path/filepath"
+	"time"
+
+	dockercontainer "github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+)
+
+// DeployRequest describes a single manuscript deployment.
+type DeployRequest struct {
+	// ManuscriptPath is the manuscript.yaml to deploy.
+	ManuscriptPath string
+	// BaseDir is the root directory manuscripts are stored under.
+	BaseDir string
+	// ManuscriptBaseName is the subdirectory under BaseDir that holds
+	// per-manuscript directories (e.g. "manuscripts").
+	ManuscriptBaseName string
+	// ConfigPath is the manuscript-cli config file to read/update.
+	ConfigPath string
+	// WaitTimeout bounds how long Deploy waits for the deployed services to
+	// become healthy before giving up. Zero uses defaultWaitTimeout.
+	WaitTimeout time.Duration
+	// Network, if set, is an existing Docker network this manuscript's
+	// containers should join instead of a dedicated per-manuscript one -
+	// used by a stack deploy so every manuscript in the stack can reach
+	// its dependencies by name. The caller owns this network's lifecycle.
+	Network string
+}
+
+// DeployResponse is the result of a successful Deploy.
+type DeployResponse struct {
+	Manuscript pkg.Manuscript
+	Dir        string
+}
+
+// LogLine is a single line of container output, tagged with the container
+// it came from.
+type LogLine struct {
+	Container string
+	Line      string
+}
+
+// Deploy validates, parses and deploys a manuscript: it reserves ports,
+// writes the manuscript's directory, brings up its Flink/Postgres/Hasura
+// containers on a dedicated network (or on req.Network, when set), and waits for every service to report
+// healthy (with exponential backoff, bounded by req.WaitTimeout) before
+// persisting it to the config.
+func Deploy(ctx context.Context, req DeployRequest) (DeployResponse, error) {
+	if err := validateManuscriptFile(req.ManuscriptPath); err != nil {
+		return DeployResponse{}, err
+	}
+
+	ms, err := pkg.ParseYAML(req.ManuscriptPath)
+	if err != nil {
+		return DeployResponse{}, fmt.Errorf("failed to parse manuscript yaml: %w", err)
+	}
+	applyDerivedFields(ms)
+
+	manuscriptDir := filepath.Join(req.BaseDir, req.ManuscriptBaseName, ms.Name)
+
+	config, err := pkg.LoadConfig(req.ConfigPath)
+	if err != nil {
+		return DeployResponse{}, fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := pkg.InitializePorts(ms, config); err != nil {
+		return DeployResponse{}, fmt.Errorf("failed to initialize ports: %w", err)
+	}
+
+	dockerClient, err := docker.NewClient()
+	if err != nil {
+		return DeployResponse{}, fmt.Errorf("failed to create docker client: %w", err)
+	}
+	defer dockerClient.Close()
+
+	if exists, err := alreadyDeployed(ctx, dockerClient, ms.Name); err != nil {
+		return DeployResponse{}, err
+	} else if exists {
+		return DeployResponse{}, fmt.Errorf("manuscript [ %s ] already deployed, please change the name in the manuscript yaml file", ms.Name)
+	}
+
+	if err := os.MkdirAll(manuscriptDir, 0755); err != nil {
+		return DeployResponse{}, fmt.Errorf("failed to create manuscript directory: %w", err)
+	}
+	if err := copyManuscriptFile(manuscriptDir, req.ManuscriptPath); err != nil {
+		return DeployResponse{}, err
+	}
+
+	networkName := req.Network
+	if networkName == "" {
+		networkName = defaultNetworkName(ms.Name)
+		if _, err := dockerClient.CreateNetwork(ctx, networkName); err != nil {
+			return DeployResponse{}, fmt.Errorf("failed to create network: %w", err)
+		}
+	}
+
+	containers, err := startServices(ctx, dockerClient, ms, networkName)
+	if err != nil {
+		return DeployResponse{}, fmt.Errorf("failed to start services: %w", err)
+	}
+
+	if err := waitForReady(ctx, dockerClient, ms, containers, req.WaitTimeout); err != nil {
+		return DeployResponse{}, fmt.Errorf("services did not become healthy: %w", err)
+	}
+
+	upsertManuscript(config, *ms)
+	if err := pkg.SaveConfig(req.ConfigPath, config); err != nil {
+		return DeployResponse{}, fmt.Errorf("failed to save config: %w", err)
+	}
+
+	return DeployResponse{Manuscript: *ms, Dir: manuscriptDir}, nil
+}
+
+// defaultNetworkName is the dedicated per-manuscript network Deploy creates
+// when the caller doesn't supply one via DeployRequest.Network.
+func defaultNetworkName(manuscriptName string) string {
+	return fmt.Sprintf("manuscript-%s", manuscriptName)
+}
+
+// Stop removes every container belonging to the named manuscript, along
+// with its dedicated network, and drops it from the config. A manuscript
+// deployed as part of a stack has no dedicated network of its own (it
+// joined the stack's shared network instead, which the stack owns), so
+// removing a network named after it is a best-effort cleanup: a not-found
+// error here just means this manuscript never had one and is logged, not
+// returned, so it doesn't fail the rest of Stop.
+func Stop(ctx context.Context, name, configPath string) error {
+	dockerClient, err := docker.NewClient()
+	if err != nil {
+		return fmt.Errorf("failed to create docker client: %w", err)
+	}
+	defer dockerClient.Close()
+
+	containers, err := dockerClient.ListContainers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	var ids []string
+	for _, c := range containers {
+		if belongsToManuscript(c.Name, name) {
+			ids = append(ids, c.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return fmt.Errorf("no containers found for manuscript [ %s ]", name)
+	}
+	if err := dockerClient.RemoveStack(ctx, ids); err != nil {
+		return fmt.Errorf("failed to remove containers: %w", err)
+	}
+
+	if err := dockerClient.RemoveNetwork(ctx, defaultNetworkName(name)); err != nil {
+		log.Printf("warning: failed to remove network for manuscript [ %s ]: %v\n", name, err)
+	}
+
+	if err := pkg.RemoveManuscriptFromConfig(configPath, name); err != nil {
+		return fmt.Errorf("failed to update config: %w", err)
+	}
+	return nil
+}
+
+// Logs streams the jobmanager container's log output for the named
+// manuscript until ctx is cancelled, closing the returned channel when the
+// stream ends.
+func Logs(ctx context.Context, name string) (<-chan LogLine, error) {
+	dockerClient, err := docker.NewClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create docker client: %w", err)
+	}
+
+	containers, err := dockerClient.ListContainers(ctx)
+	if err != nil {
+		dockerClient.Close()
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	var containerID string
+	for _, c := range containers {
+		if c.Name == fmt.Sprintf("%s-jobmanager-1", name) {
+			containerID = c.ID
+			break
+		}
+	}
+	if containerID == "" {
+		dockerClient.Close()
+		return nil, fmt.Errorf("no jobmanager container found for manuscript [ %s ]", name)
+	}
+
+	rc, err := dockerClient.StreamLogs(ctx, containerID)
+	if err != nil {
+		dockerClient.Close()
+		return nil, err
+	}
+
+	lines := make(chan LogLine)
+	go func() {
+		defer close(lines)
+		defer dockerClient.Close()
+		defer rc.Close()
+
+		scanner := bufio.NewScanner(rc)
+		for scanner.Scan() {
+			select {
+			case lines <- LogLine{Container: containerID, Line: scanner.Text()}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return lines, nil
+}
+
+// List returns every manuscript currently recorded in the config.
+func List(configPath string) ([]pkg.Manuscript, error) {
+	config, err := pkg.LoadConfig(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	return config.Manuscripts, nil
+}
+
+// InitializePorts reserves Flink/GraphQL/DB ports for ms against the
+// manuscripts already recorded at configPath.
+func InitializePorts(ms *pkg.Manuscript, configPath string) error {
+	config, err := pkg.LoadConfig(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	return pkg.InitializePorts(ms, config)
+}
+
+// upsertManuscript adds ms to config.Manuscripts, replacing any existing
+// entry with the same name so a re-deploy updates in place instead of
+// duplicating, and so a multi-manuscript stack deploy accumulates every
+// manuscript it deploys rather than clobbering the ones before it.
+func upsertManuscript(config *pkg.Config, ms pkg.Manuscript) {
+	for i, existing := range config.Manuscripts {
+		if existing.Name == ms.Name {
+			config.Manuscripts[i] = ms
+			return
+		}
+	}
+	config.Manuscripts = append(config.Manuscripts, ms)
+}
+
+func applyDerivedFields(ms *pkg.Manuscript) {
+	if len(ms.Sinks) != 0 {
+		ms.Table = ms.Sinks[0].Table
+		ms.Database = ms.Sinks[0].Database
+		if ms.Sinks[0].Type == "postgres" {
+			ms.Sink = "postgres"
+		}
+	}
+	if len(ms.Sources) != 0 {
+		ms.Chain = ms.Sources[0].Dataset
+	}
+	if len(ms.Transforms) != 0 {
+		ms.Query = ms.Transforms[0].SQL
+	}
+}
+
+func validateManuscriptFile(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read manuscript file: %w", err)
+	}
+	if len(content) == 0 {
+		return fmt.Errorf("manuscript file is empty")
+	}
+	return nil
+}
+
+func copyManuscriptFile(manuscriptDir, manuscriptPath string) error {
+	content, err := os.ReadFile(manuscriptPath)
+	if err != nil {
+		return fmt.Errorf("failed to read source file: %w", err)
+	}
+
+	_, fileName := filepath.Split(manuscriptPath)
+	destinationPath := filepath.Join(manuscriptDir, fileName)
+
+	tempFile := destinationPath + ".tmp"
+	if err := os.WriteFile(tempFile, content, 0644); err != nil {
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := os.Rename(tempFile, destinationPath); err != nil {
+		os.Remove(tempFile)
+		return fmt.Errorf("failed to rename temporary file: %w", err)
+	}
+	return nil
+}
+
+func alreadyDeployed(ctx context.Context, dockerClient *docker.Client, name string) (bool, error) {
+	containers, err := dockerClient.ListContainers(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to list docker containers: %w", err)
+	}
+	for _, c := range containers {
+		if c.Name == fmt.Sprintf("%s-jobmanager-1", name) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// manuscriptServiceSuffixes lists the container-name suffixes startServices
+// creates, e.g. "foo-jobmanager-1" for manuscript "foo".
+var manuscriptServiceSuffixes = []string{"-jobmanager-1", "-taskmanager-1", "-postgres-1", "-hasura-1"}
+
+// belongsToManuscript reports whether containerName is one of
+// manuscriptName's own service containers. It anchors the match to the
+// known service suffixes rather than a bare prefix, so stopping manuscript
+// "foo" doesn't also match containers belonging to "foo-v2".
+func belongsToManuscript(containerName, manuscriptName string) bool {
+	for _, suffix := range manuscriptServiceSuffixes {
+		if containerName == manuscriptName+suffix {
+			return true
+		}
+	}
+	return false
+}
+
+// flinkTaskSlots is the taskmanager.numberOfTaskSlots given to the
+// taskmanager container, matching the default used in Flink's own
+// docker-compose examples.
+const flinkTaskSlots = 2
+
+// startServices brings up the jobmanager, taskmanager, postgres and hasura
+// containers for ms on the given network, analogous to the services the
+// old generated docker-compose.yaml declared. It returns the created
+// container IDs keyed by service name (e.g. "jobmanager") so callers can
+// wait on the specific containers they care about. The jobmanager, postgres
+// and hasura containers get a healthcheck so Health.Status is actually
+// populated for WaitHealthy to poll; the taskmanager has none, so
+// WaitHealthy treats it as ready once it is running.
+func startServices(ctx context.Context, dockerClient *docker.Client, ms *pkg.Manuscript, networkName string) (map[string]string, error) {
+	jobmanagerName := fmt.Sprintf("%s-jobmanager-1", ms.Name)
+
+	services := []struct {
+		key           string
+		name          string
+		cfg           *dockercontainer.Config
+		publishOn     int // host port to publish containerPort on; 0 means don't publish
+		containerPort int
+	}{
+		{
+			key:           "jobmanager",
+			name:          jobmanagerName,
+			publishOn:     ms.Port,
+			containerPort: ms.Port,
+			cfg: &dockercontainer.Config{
+				Image: "flink:1.17",
+				Cmd:   []string{"jobmanager"},
+				Env: []string{fmt.Sprintf(
+					"FLINK_PROPERTIES=jobmanager.rpc.address: %s\nrest.port: %d",
+					jobmanagerName, ms.Port,
+				)},
+				Healthcheck: &dockercontainer.HealthConfig{
+					Test:     []string{"CMD-SHELL", fmt.Sprintf("curl -sf http://localhost:%d/overview || exit 1", ms.Port)},
+					Interval: 5 * time.Second,
+					Timeout:  3 * time.Second,
+					Retries:  5,
+				},
+			},
+		},
+		{
+			key:  "taskmanager",
+			name: fmt.Sprintf("%s-taskmanager-1", ms.Name),
+			cfg: &dockercontainer.Config{
+				Image: "flink:1.17",
+				Cmd:   []string{"taskmanager"},
+				Env: []string{fmt.Sprintf(
+					"FLINK_PROPERTIES=jobmanager.rpc.address: %s\ntaskmanager.numberOfTaskSlots: %d",
+					jobmanagerName, flinkTaskSlots,
+				)},
+			},
+		},
+		{
+			key:           "postgres",
+			name:          fmt.Sprintf("%s-postgres-1", ms.Name),
+			publishOn:     ms.DbPort,
+			containerPort: 5432,
+			cfg: &dockercontainer.Config{
+				Image: "postgres:15",
+				Env:   []string{fmt.Sprintf("POSTGRES_DB=%s", ms.Database)},
+				Healthcheck: &dockercontainer.HealthConfig{
+					Test:     []string{"CMD-SHELL", "pg_isready -U postgres"},
+					Interval: 5 * time.Second,
+					Timeout:  3 * time.Second,
+					Retries:  5,
+				},
+			},
+		},
+		{
+			key:           "hasura",
+			name:          fmt.Sprintf("%s-hasura-1", ms.Name),
+			publishOn:     ms.GraphQLPort,
+			containerPort: 8080,
+			cfg: &dockercontainer.Config{
+				Image: "hasura/graphql-engine:v2.36.0",
+				Healthcheck: &dockercontainer.HealthConfig{
+					Test:     []string{"CMD-SHELL", "curl -sf http://localhost:8080/healthz || exit 1"},
+					Interval: 5 * time.Second,
+					Timeout:  3 * time.Second,
+					Retries:  5,
+				},
+			},
+		},
+	}
+
+	created := make(map[string]string, len(services))
+	var createdIDs []string
+	for _, svc := range services {
+		hostCfg := &dockercontainer.HostConfig{
+			NetworkMode: dockercontainer.NetworkMode(networkName),
+		}
+		if svc.publishOn != 0 {
+			containerPort, err := nat.NewPort("tcp", fmt.Sprintf("%d", svc.containerPort))
+			if err != nil {
+				return nil, fmt.Errorf("failed to build port spec for %s: %w", svc.name, err)
+			}
+			svc.cfg.ExposedPorts = nat.PortSet{containerPort: struct{}{}}
+			hostCfg.PortBindings = nat.PortMap{
+				containerPort: []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: fmt.Sprintf("%d", svc.publishOn)}},
+			}
+		}
+
+		id, err := dockerClient.CreateContainer(ctx, svc.name, svc.cfg, hostCfg)
+		if err != nil {
+			dockerClient.RemoveStack(ctx, createdIDs)
+			return nil, fmt.Errorf("failed to create %s: %w", svc.name, err)
+		}
+		created[svc.key] = id
+		createdIDs = append(createdIDs, id)
+
+		if err := dockerClient.StartService(ctx, id); err != nil {
+			dockerClient.RemoveStack(ctx, createdIDs)
+			return nil, fmt.Errorf("failed to start %s: %w", svc.name, err)
+		}
+	}
+	return created, nil
+}
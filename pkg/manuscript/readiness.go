@@ -0,0 +1,127 @@
+package manuscript
+
+import (
+	"context"
+	"fmt"
+	"manuscript-core/pkg"
+	"manuscript-core/pkg/docker"
+	"net/http"
+	"time"
+)
+
+// defaultWaitTimeout is used whenever a caller doesn't set
+// DeployRequest.WaitTimeout, matching the --wait=90s default the deploy
+// command exposes.
+const defaultWaitTimeout = 90 * time.Second
+
+// pollBackoffInitial and pollBackoffMax mirror docker.Client.WaitHealthy's
+// own backoff bounds, so the Flink/Hasura HTTP probes back off the same way
+// the container health polling does.
+const (
+	pollBackoffInitial = 500 * time.Millisecond
+	pollBackoffMax     = 8 * time.Second
+)
+
+// waitForReady blocks until every container in services reports healthy and
+// the Flink/Hasura HTTP endpoints respond, surfacing progress through
+// pkg.ExecuteStepWithLoading so the step-by-step spinner shows which
+// service is still coming up. This replaces a one-shot check run
+// immediately after `docker compose up`, which raced Flink/Hasura startup
+// and produced spurious failures on slower machines.
+//
+// The ms.Port/ms.GraphQLPort HTTP probes below only succeed because
+// startServices publishes those containers' ports to the host via
+// PortBindings; without that, every poll here hits a closed host port and
+// this always times out.
+func waitForReady(ctx context.Context, dockerClient *docker.Client, ms *pkg.Manuscript, services map[string]string, timeout time.Duration) error {
+	if timeout <= 0 {
+		timeout = defaultWaitTimeout
+	}
+	deadline := time.Now().Add(timeout)
+
+	for _, key := range []string{"postgres", "jobmanager", "taskmanager", "hasura"} {
+		id, ok := services[key]
+		if !ok {
+			continue
+		}
+		remaining, err := timeUntil(deadline)
+		if err != nil {
+			return err
+		}
+		stepName := fmt.Sprintf("Waiting for %s to become healthy", key)
+		if err := pkg.ExecuteStepWithLoading(stepName, true, func() error {
+			return dockerClient.WaitHealthy(ctx, id, remaining)
+		}); err != nil {
+			return err
+		}
+	}
+
+	remaining, err := timeUntil(deadline)
+	if err != nil {
+		return err
+	}
+	if err := pkg.ExecuteStepWithLoading("Waiting for Flink REST endpoint", true, func() error {
+		return pollHTTP(ctx, fmt.Sprintf("http://localhost:%d/overview", ms.Port), remaining)
+	}); err != nil {
+		return err
+	}
+
+	remaining, err = timeUntil(deadline)
+	if err != nil {
+		return err
+	}
+	if err := pkg.ExecuteStepWithLoading("Waiting for Hasura healthz endpoint", true, func() error {
+		return pollHTTP(ctx, fmt.Sprintf("http://localhost:%d/healthz", ms.GraphQLPort), remaining)
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// timeUntil returns the time remaining until deadline, or an error if it
+// has already passed - used to spend a single overall timeout budget across
+// waitForReady's health checks and HTTP probes instead of giving each one
+// the full timeout.
+func timeUntil(deadline time.Time) (time.Duration, error) {
+	remaining := time.Until(deadline)
+	if remaining <= 0 {
+		return 0, fmt.Errorf("timed out waiting for services to become healthy")
+	}
+	return remaining, nil
+}
+
+// pollHTTP retries a GET against url with the same exponential backoff as
+// WaitHealthy (500ms -> 8s) until it returns 2xx or timeout elapses.
+func pollHTTP(ctx context.Context, url string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	backoff := pollBackoffInitial
+
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err == nil {
+			resp, err := http.DefaultClient.Do(req)
+			if err == nil {
+				resp.Body.Close()
+				if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+					return nil
+				}
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for %s to respond", url)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > pollBackoffMax {
+			backoff = pollBackoffMax
+		}
+	}
+}
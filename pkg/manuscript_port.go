@@ -1,12 +1,10 @@
 package pkg
 
 import (
-	"bufio"
-	"bytes"
+	"context"
 	"fmt"
-	"os/exec"
-	"regexp"
-	"strconv"
+
+	"manuscript-core/pkg/docker"
 )
 
 type PortReservation struct {
@@ -155,67 +153,57 @@ func InitializePorts(ms *Manuscript, config *Config) error {
 	return nil
 }
 
+// GetListeningPorts returns every port manuscript-core should treat as
+// occupied: ports bound by the host (via the platform-specific
+// listeningPorts implementation in ports_linux.go/ports_darwin.go/
+// ports_windows.go) plus ports already published by Docker containers.
 func GetListeningPorts() ([]int, error) {
 	ports := make(map[int]bool)
 
-	// Check system ports using lsof
-	cmd := exec.Command("lsof", "-nP", "-iTCP", "-sTCP:LISTEN")
-	var out bytes.Buffer
-	cmd.Stdout = &out
-
-	if err := cmd.Run(); err != nil {
-		// Don't return error here, continue to check Docker ports
-		fmt.Printf("Warning: Unable to check system ports: %v\n", err)
-	} else {
-		re := regexp.MustCompile(`:(\d+)\s+\(LISTEN\)`)
-		scanner := bufio.NewScanner(&out)
-		for scanner.Scan() {
-			line := scanner.Text()
-			matches := re.FindStringSubmatch(line)
-			if len(matches) > 1 {
-				port, err := strconv.Atoi(matches[1])
-				if err != nil {
-					continue
-				}
-				ports[port] = true
-			}
-		}
+	hostPorts, err := listeningPorts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check system ports: %w", err)
+	}
+	for _, port := range hostPorts {
+		ports[port] = true
 	}
 
-	// Check Docker container ports
-	dockerCmd := exec.Command("docker", "ps", "--format", "{{.Ports}}")
-	var dockerOut bytes.Buffer
-	dockerCmd.Stdout = &dockerOut
-
-	if err := dockerCmd.Run(); err != nil {
+	dockerPorts, err := dockerPublishedPorts()
+	if err != nil {
 		return nil, fmt.Errorf("failed to check Docker ports: %w", err)
 	}
-
-	// Parse Docker port mappings
-	scanner := bufio.NewScanner(&dockerOut)
-	portRegex := regexp.MustCompile(`0\.0\.0\.0:(\d+)`)
-	for scanner.Scan() {
-		line := scanner.Text()
-		matches := portRegex.FindAllStringSubmatch(line, -1)
-		for _, match := range matches {
-			if len(match) > 1 {
-				port, err := strconv.Atoi(match[1])
-				if err != nil {
-					continue
-				}
-				ports[port] = true
-			}
-		}
+	for _, port := range dockerPorts {
+		ports[port] = true
 	}
 
-	// Convert map to slice
-	var result []int
+	result := make([]int, 0, len(ports))
 	for port := range ports {
 		result = append(result, port)
 	}
 	return result, nil
 }
 
+// dockerPublishedPorts lists the host ports currently published by Docker
+// containers, via the Docker Engine SDK rather than parsing `docker ps`.
+func dockerPublishedPorts() ([]int, error) {
+	cli, err := docker.NewClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create docker client: %w", err)
+	}
+	defer cli.Close()
+
+	containers, err := cli.ListContainers(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	var ports []int
+	for _, c := range containers {
+		ports = append(ports, c.PublishedPorts...)
+	}
+	return ports, nil
+}
+
 func FindAvailablePort(startPort, endPort int, unavailablePorts map[int]bool) (int, error) {
 	for port := startPort; port <= endPort; port++ {
 		if !unavailablePorts[port] {
@@ -0,0 +1,34 @@
+//go:build darwin
+
+package pkg
+
+import (
+	"fmt"
+	"net"
+)
+
+// darwinProbeRanges mirrors the port ranges FindAvailablePort hands out
+// (see InitializePorts), since those are the only ports manuscript-core
+// ever needs to know are taken.
+var darwinProbeRanges = [][2]int{
+	{8081, 8182},
+	{15432, 15532},
+}
+
+// listeningPorts has no lsof-free way to enumerate every listening socket
+// on macOS without cgo, so instead it directly probes the candidate port
+// ranges with net.Listen: if binding fails, something is already listening.
+func listeningPorts() ([]int, error) {
+	var ports []int
+	for _, r := range darwinProbeRanges {
+		for port := r[0]; port <= r[1]; port++ {
+			ln, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+			if err != nil {
+				ports = append(ports, port)
+				continue
+			}
+			ln.Close()
+		}
+	}
+	return ports, nil
+}
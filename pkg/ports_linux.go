@@ -0,0 +1,83 @@
+//go:build linux
+
+package pkg
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// tcpListenState is the /proc/net/tcp "st" column value for sockets in
+// LISTEN state (see linux/include/net/tcp_states.h: TCP_LISTEN == 0x0A).
+const tcpListenState = "0A"
+
+// listeningPorts reads /proc/net/tcp and /proc/net/tcp6 directly, avoiding a
+// dependency on the `lsof` binary which is frequently missing from minimal
+// container images.
+func listeningPorts() ([]int, error) {
+	ports := make(map[int]bool)
+
+	for _, path := range []string{"/proc/net/tcp", "/proc/net/tcp6"} {
+		if err := parseProcNetTCP(path, ports); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+	}
+
+	result := make([]int, 0, len(ports))
+	for port := range ports {
+		result = append(result, port)
+	}
+	return result, nil
+}
+
+// parseProcNetTCP parses a /proc/net/tcp(6) file, adding the local port of
+// every socket in LISTEN state to ports.
+func parseProcNetTCP(path string, ports map[int]bool) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := parseProcNetTCPReader(f, ports); err != nil {
+		return fmt.Errorf("failed to scan %s: %w", path, err)
+	}
+	return nil
+}
+
+// parseProcNetTCPReader does the actual line parsing for parseProcNetTCP,
+// split out so it can be exercised directly against an in-memory fixture.
+func parseProcNetTCPReader(r io.Reader, ports map[int]bool) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Scan() // skip header line
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 {
+			continue
+		}
+		if fields[3] != tcpListenState {
+			continue
+		}
+
+		localAddr := fields[1] // "<hex addr>:<hex port>"
+		parts := strings.Split(localAddr, ":")
+		if len(parts) != 2 {
+			continue
+		}
+
+		port, err := strconv.ParseInt(parts[1], 16, 32)
+		if err != nil {
+			continue
+		}
+		ports[int(port)] = true
+	}
+	return scanner.Err()
+}
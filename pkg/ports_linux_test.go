@@ -0,0 +1,64 @@
+//go:build linux
+
+package pkg
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseProcNetTCPReader(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  map[int]bool
+	}{
+		{
+			name: "single listening socket",
+			input: "  sl  local_address rem_address   st\n" +
+				"   0: 0100007F:1F90 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 12345 1 0000000000000000 100 0 0 10 0\n",
+			want: map[int]bool{8080: true},
+		},
+		{
+			name: "non-listening socket is ignored",
+			input: "  sl  local_address rem_address   st\n" +
+				"   0: 0100007F:1F90 00000000:0000 01 00000000:00000000 00:00000000 00000000     0        0 12345 1 0000000000000000 100 0 0 10 0\n",
+			want: map[int]bool{},
+		},
+		{
+			name: "multiple listening sockets",
+			input: "  sl  local_address rem_address   st\n" +
+				"   0: 00000000:0050 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 12345 1 0000000000000000 100 0 0 10 0\n" +
+				"   1: 00000000:1BB9 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 12346 1 0000000000000000 100 0 0 10 0\n",
+			want: map[int]bool{80: true, 7097: true},
+		},
+		{
+			name:  "header only",
+			input: "  sl  local_address rem_address   st\n",
+			want:  map[int]bool{},
+		},
+		{
+			name: "malformed line is skipped",
+			input: "  sl  local_address rem_address   st\n" +
+				"   0: not-an-address 00000000:0000 0A\n",
+			want: map[int]bool{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ports := make(map[int]bool)
+			if err := parseProcNetTCPReader(strings.NewReader(tt.input), ports); err != nil {
+				t.Fatalf("parseProcNetTCPReader returned error: %v", err)
+			}
+			if len(ports) != len(tt.want) {
+				t.Fatalf("got ports %v, want %v", ports, tt.want)
+			}
+			for port := range tt.want {
+				if !ports[port] {
+					t.Errorf("expected port %d to be listening, got %v", port, ports)
+				}
+			}
+		})
+	}
+}
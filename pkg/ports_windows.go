@@ -0,0 +1,67 @@
+//go:build windows
+
+package pkg
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// golang.org/x/sys/windows doesn't wrap GetExtendedTcpTable - it has to be
+// loaded from iphlpapi.dll directly, the same way other Go projects (e.g.
+// gopsutil) call into it.
+var (
+	modIPHlpAPI             = windows.NewLazySystemDLL("iphlpapi.dll")
+	procGetExtendedTCPTable = modIPHlpAPI.NewProc("GetExtendedTcpTable")
+)
+
+const (
+	tcpTableOwnerPIDAll = 5
+	mibTCPStateListen   = 2
+)
+
+type mibTCPRowOwnerPID struct {
+	State      uint32
+	LocalAddr  uint32
+	LocalPort  uint32
+	RemoteAddr uint32
+	RemotePort uint32
+	OwningPid  uint32
+}
+
+// listeningPorts calls the IP Helper API's GetExtendedTcpTable to enumerate
+// listening TCP sockets, avoiding any dependency on external tools (lsof
+// doesn't exist on Windows in the first place).
+func listeningPorts() ([]int, error) {
+	var size uint32
+	ret, _, _ := procGetExtendedTCPTable.Call(
+		0, uintptr(unsafe.Pointer(&size)), 0, windows.AF_INET, tcpTableOwnerPIDAll, 0,
+	)
+	if ret != 0 && ret != uintptr(windows.ERROR_INSUFFICIENT_BUFFER) {
+		return nil, fmt.Errorf("failed to size TCP table: error code %d", ret)
+	}
+
+	buf := make([]byte, size)
+	ret, _, _ = procGetExtendedTCPTable.Call(
+		uintptr(unsafe.Pointer(&buf[0])), uintptr(unsafe.Pointer(&size)), 0, windows.AF_INET, tcpTableOwnerPIDAll, 0,
+	)
+	if ret != 0 {
+		return nil, fmt.Errorf("failed to read TCP table: error code %d", ret)
+	}
+
+	numEntries := *(*uint32)(unsafe.Pointer(&buf[0]))
+	rows := (*[1 << 20]mibTCPRowOwnerPID)(unsafe.Pointer(&buf[4]))[:numEntries:numEntries]
+
+	var ports []int
+	for _, row := range rows {
+		if row.State != mibTCPStateListen {
+			continue
+		}
+		// LocalPort is stored in network byte order in the low 16 bits.
+		port := int((row.LocalPort>>8)&0xff | (row.LocalPort&0xff)<<8)
+		ports = append(ports, port)
+	}
+	return ports, nil
+}